@@ -0,0 +1,34 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import "net/http"
+
+// Binding decodes the contents of a request into obj. It does not
+// validate the decoded fields.
+type Binding interface {
+	Bind(*http.Request, interface{}) error
+}
+
+var (
+	JSON = jsonBinding{}
+	XML  = xmlBinding{}
+	Form = formBinding{}
+)
+
+// Default picks a Binding based on the HTTP method and Content-Type,
+// falling back to JSON.
+func Default(method, contentType string) Binding {
+	if method == "GET" {
+		return Form
+	}
+
+	switch contentType {
+	case "application/xml", "text/xml":
+		return XML
+	default: // case json, or anything else
+		return JSON
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package binding
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ValuesSource returns the raw string values available for name, e.g.
+// the path parameters, query string, headers or form fields of a
+// request. Returning nil or an empty slice means the value is absent.
+type ValuesSource func(name string) []string
+
+// UnixTimePrecision selects how a Unix timestamp is interpreted by
+// ValueBinder.UnixTime.
+type UnixTimePrecision int
+
+const (
+	UnixTimeSeconds UnixTimePrecision = iota
+	UnixTimeMilliseconds
+	UnixTimeNanoseconds
+)
+
+// ValueBinder extracts and coerces values one field at a time from a
+// ValuesSource. Every method returns the binder itself so extractions
+// can be chained; a failed coercion is reported to errorFunc and
+// recorded, but does not stop the remaining chain from running. Call
+// BindError once the chain is done to check whether anything failed.
+type ValueBinder struct {
+	source    ValuesSource
+	errorFunc func(error)
+	errs      []error
+}
+
+// NewValueBinder creates a ValueBinder over source. errorFunc, if not
+// nil, is invoked with every coercion error as it happens.
+func NewValueBinder(source ValuesSource, errorFunc func(error)) *ValueBinder {
+	return &ValueBinder{source: source, errorFunc: errorFunc}
+}
+
+// BindError returns the first error recorded while binding, or nil if
+// every field bound successfully.
+func (b *ValueBinder) BindError() error {
+	if len(b.errs) == 0 {
+		return nil
+	}
+	return b.errs[0]
+}
+
+// Invalid records err against name whenever err is non-nil,
+// independent of any source value, useful for
+// surfacing a source-level failure (e.g. a malformed request body)
+// rather than a single field's coercion failure.
+func (b *ValueBinder) Invalid(name string, err error) *ValueBinder {
+	if err != nil {
+		b.fail(name, err)
+	}
+	return b
+}
+
+func (b *ValueBinder) fail(name string, err error) {
+	wrapped := fmt.Errorf("failed to bind %q: %w", name, err)
+	b.errs = append(b.errs, wrapped)
+	if b.errorFunc != nil {
+		b.errorFunc(wrapped)
+	}
+}
+
+func (b *ValueBinder) values(name string) []string {
+	if b.source == nil {
+		return nil
+	}
+	return b.source(name)
+}
+
+func (b *ValueBinder) value(name string) (string, bool) {
+	values := b.values(name)
+	if len(values) == 0 || values[0] == "" {
+		return "", false
+	}
+	return values[0], true
+}
+
+// String extracts a single string value into dest.
+func (b *ValueBinder) String(name string, dest *string) *ValueBinder {
+	if value, ok := b.value(name); ok {
+		*dest = value
+	}
+	return b
+}
+
+// Strings extracts every value for name into dest.
+func (b *ValueBinder) Strings(name string, dest *[]string) *ValueBinder {
+	if values := b.values(name); len(values) > 0 {
+		*dest = values
+	}
+	return b
+}
+
+// Int64 extracts and parses a base-10 integer into dest.
+func (b *ValueBinder) Int64(name string, dest *int64) *ValueBinder {
+	value, ok := b.value(name)
+	if !ok {
+		return b
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		b.fail(name, err)
+		return b
+	}
+	*dest = n
+	return b
+}
+
+// Int64s extracts and parses every value for name into dest.
+func (b *ValueBinder) Int64s(name string, dest *[]int64) *ValueBinder {
+	values := b.values(name)
+	if len(values) == 0 {
+		return b
+	}
+	result := make([]int64, len(values))
+	for i, value := range values {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			b.fail(name, err)
+			return b
+		}
+		result[i] = n
+	}
+	*dest = result
+	return b
+}
+
+// Uint64 extracts and parses a base-10 unsigned integer into dest.
+func (b *ValueBinder) Uint64(name string, dest *uint64) *ValueBinder {
+	value, ok := b.value(name)
+	if !ok {
+		return b
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		b.fail(name, err)
+		return b
+	}
+	*dest = n
+	return b
+}
+
+// Float64 extracts and parses a floating point number into dest.
+func (b *ValueBinder) Float64(name string, dest *float64) *ValueBinder {
+	value, ok := b.value(name)
+	if !ok {
+		return b
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		b.fail(name, err)
+		return b
+	}
+	*dest = n
+	return b
+}
+
+// Bool extracts and parses a boolean into dest.
+func (b *ValueBinder) Bool(name string, dest *bool) *ValueBinder {
+	value, ok := b.value(name)
+	if !ok {
+		return b
+	}
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		b.fail(name, err)
+		return b
+	}
+	*dest = v
+	return b
+}
+
+// Duration extracts and parses a time.Duration into dest.
+func (b *ValueBinder) Duration(name string, dest *time.Duration) *ValueBinder {
+	value, ok := b.value(name)
+	if !ok {
+		return b
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		b.fail(name, err)
+		return b
+	}
+	*dest = d
+	return b
+}
+
+// Time extracts and parses a time.Time formatted according to layout
+// into dest.
+func (b *ValueBinder) Time(name, layout string, dest *time.Time) *ValueBinder {
+	value, ok := b.value(name)
+	if !ok {
+		return b
+	}
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		b.fail(name, err)
+		return b
+	}
+	*dest = t
+	return b
+}
+
+// UnixTime extracts a Unix timestamp at the given precision into dest.
+func (b *ValueBinder) UnixTime(name string, precision UnixTimePrecision, dest *time.Time) *ValueBinder {
+	value, ok := b.value(name)
+	if !ok {
+		return b
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		b.fail(name, err)
+		return b
+	}
+	switch precision {
+	case UnixTimeMilliseconds:
+		// time.UnixMilli was only added in Go 1.17; decode by hand so
+		// this keeps working for callers on older toolchains.
+		*dest = time.Unix(n/1e3, (n%1e3)*1e6)
+	case UnixTimeNanoseconds:
+		*dest = time.Unix(0, n)
+	default:
+		*dest = time.Unix(n, 0)
+	}
+	return b
+}
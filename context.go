@@ -0,0 +1,353 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+	"github.com/julienschmidt/httprouter"
+)
+
+const AbortIndex = math.MaxInt8 / 2
+
+// Context is the most important part of gin. It allows us to pass
+// variables between middleware, manage the flow, validate the JSON of
+// a request and render a JSON response for example.
+type Context struct {
+	writermem responseWriter
+	Request   *http.Request
+	Writer    ResponseWriter
+
+	Params   httprouter.Params
+	handlers HandlersChain
+	index    int
+
+	Engine   *Engine
+	Keys     map[string]interface{}
+	Errors   errorMsgs
+	Accepted []string
+}
+
+func (c *Context) reset() {
+	c.Writer = &c.writermem
+	c.Params = c.Params[0:0]
+	c.handlers = nil
+	c.index = -1
+	c.Keys = nil
+	c.Errors = c.Errors[0:0]
+	c.Accepted = nil
+}
+
+// Next should be used only inside middleware. It executes the pending
+// handlers in the chain, only calling the next one if the current one
+// does not abort.
+func (c *Context) Next() {
+	c.index++
+	for s := len(c.handlers); c.index < s; c.index++ {
+		c.handlers[c.index](c)
+	}
+}
+
+// IsAborted returns true if the current context was aborted.
+func (c *Context) IsAborted() bool {
+	return c.index >= AbortIndex
+}
+
+// Abort prevents pending handlers from being called. It does not stop
+// the current handler.
+func (c *Context) Abort() {
+	c.index = AbortIndex
+}
+
+// AbortWithStatus calls Abort and writes the given status code.
+func (c *Context) AbortWithStatus(code int) {
+	c.Writer.WriteHeader(code)
+	c.Abort()
+}
+
+// Fail is the same as AbortWithStatus, but also records err as the
+// last error for the context.
+func (c *Context) Fail(code int, err error) {
+	c.Error(err, "Operation aborted")
+	c.AbortWithStatus(code)
+}
+
+// Set stores a value for the lifetime of the current request, making
+// it available to handlers further down the chain via Get.
+func (c *Context) Set(key string, item interface{}) {
+	if c.Keys == nil {
+		c.Keys = make(map[string]interface{})
+	}
+	c.Keys[key] = item
+}
+
+// Get returns the value for the given key and whether it exists.
+func (c *Context) Get(key string) (value interface{}, exists bool) {
+	if c.Keys != nil {
+		value, exists = c.Keys[key]
+	}
+	return
+}
+
+// MustGet returns the value for the given key, panicking if it does
+// not exist.
+func (c *Context) MustGet(key string) interface{} {
+	value, exists := c.Get(key)
+	if !exists || value == nil {
+		panic("Key \"" + key + "\" does not exist")
+	}
+	return value
+}
+
+// Error attaches an error to the current context, defaulting to
+// ErrorTypeExternal. It is pushed to the c.Errors list.
+func (c *Context) Error(err error, meta interface{}) {
+	c.ErrorTyped(err, ErrorTypeExternal, meta)
+}
+
+// ErrorTyped attaches an error to the current context, tagging it with
+// typ so callers can later filter errors with c.Errors.ByType.
+func (c *Context) ErrorTyped(err error, typ ErrorType, meta interface{}) {
+	c.Errors = append(c.Errors, ErrorMsg{
+		Err:  err.Error(),
+		Type: typ,
+		Meta: meta,
+	})
+}
+
+// LastError returns the last error attached to the context, or nil if
+// there are none.
+func (c *Context) LastError() error {
+	if length := len(c.Errors); length > 0 {
+		return errors.New(c.Errors[length-1].Err)
+	}
+	return nil
+}
+
+// JSON serializes obj as JSON and writes it with the given status
+// code. If the request has a `?pretty` query parameter, the response
+// is indented with two spaces, mirroring the auto-pretty behavior
+// other frameworks provide.
+func (c *Context) JSON(code int, obj interface{}) {
+	if c.Request != nil {
+		if _, pretty := c.Request.URL.Query()["pretty"]; pretty {
+			c.JSONPretty(code, obj, "  ")
+			return
+		}
+	}
+	c.Writer.WriteHeader(code)
+	if err := (render.JSON{Data: obj}).Render(c.Writer); err != nil {
+		c.Error(err, nil)
+	}
+}
+
+// JSONPretty serializes obj as JSON indented with indent and writes it
+// with the given status code.
+func (c *Context) JSONPretty(code int, obj interface{}, indent string) {
+	c.Writer.WriteHeader(code)
+	if err := (render.IndentedJSON{Data: obj, Indent: indent}).Render(c.Writer); err != nil {
+		c.Error(err, nil)
+	}
+}
+
+// JSONP serializes obj as JSON wrapped in the callback named by the
+// request's `callback` query parameter, falling back to plain JSON
+// when no valid callback is present. The callback name is validated
+// against a JavaScript identifier before it is echoed back, to keep a
+// malicious query parameter from injecting a script into the response.
+func (c *Context) JSONP(code int, obj interface{}) {
+	c.Writer.WriteHeader(code)
+	callback := c.Request.URL.Query().Get("callback")
+	if err := (render.JSONP{Data: obj, Callback: callback}).Render(c.Writer); err != nil {
+		c.Error(err, nil)
+	}
+}
+
+// XML serializes obj as XML and writes it with the given status code.
+func (c *Context) XML(code int, obj interface{}) {
+	c.Writer.WriteHeader(code)
+	if err := (render.XML{Data: obj}).Render(c.Writer); err != nil {
+		c.Error(err, nil)
+	}
+}
+
+// HTML renders the named template from the Engine's HTMLRender with
+// data obj and writes it with the given status code.
+func (c *Context) HTML(code int, name string, obj interface{}) {
+	c.Writer.WriteHeader(code)
+	instance := c.Engine.HTMLRender.Instance(name, obj)
+	if err := instance.Render(c.Writer); err != nil {
+		c.Error(err, nil)
+	}
+}
+
+// String writes a formatted string with the given status code and
+// Content-Type text/plain.
+func (c *Context) String(code int, format string, values ...interface{}) {
+	c.Writer.WriteHeader(code)
+	if err := (render.String{Format: format, Data: values}).Render(c.Writer); err != nil {
+		c.Error(err, nil)
+	}
+}
+
+// HTMLString writes a formatted string with the given status code and
+// Content-Type text/html.
+func (c *Context) HTMLString(code int, format string, values ...interface{}) {
+	c.Writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	c.Writer.WriteHeader(code)
+	if len(values) > 0 {
+		fmt.Fprintf(c.Writer, format, values...)
+	} else {
+		fmt.Fprint(c.Writer, format)
+	}
+}
+
+// Data writes raw bytes with the given status code and Content-Type.
+func (c *Context) Data(code int, contentType string, data []byte) {
+	c.Writer.WriteHeader(code)
+	if err := (render.Data{ContentType: contentType, Data: data}).Render(c.Writer); err != nil {
+		c.Error(err, nil)
+	}
+}
+
+// Redirect returns an HTTP redirect to the specific location, using
+// code as the HTTP status (301, 302, ...).
+func (c *Context) Redirect(code int, location string) {
+	if err := (render.Redirect{Code: code, Location: location, Request: c.Request}).Render(c.Writer); err != nil {
+		c.Error(err, nil)
+	}
+}
+
+// SetAccepted sets the Accept formats the context will consider when
+// negotiating a response format.
+func (c *Context) SetAccepted(formats ...string) {
+	c.Accepted = formats
+}
+
+// NegotiateFormat returns the best match between the request's Accept
+// header (or the formats set with SetAccepted) and offered, or an
+// empty string if none match.
+func (c *Context) NegotiateFormat(offered ...string) string {
+	if len(offered) == 0 {
+		panic("you must provide at least one offer")
+	}
+
+	if c.Accepted == nil {
+		c.Accepted = parseAccept(c.Request.Header.Get("Accept"))
+	}
+	if len(c.Accepted) == 0 {
+		return offered[0]
+	}
+	for _, accepted := range c.Accepted {
+		for _, offer := range offered {
+			if accepted == offer {
+				return offer
+			}
+		}
+	}
+	return ""
+}
+
+func parseAccept(acceptHeader string) []string {
+	parts := strings.Split(acceptHeader, ",")
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		index := strings.IndexByte(part, ';')
+		if index >= 0 {
+			part = part[0:index]
+		}
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// ContentType returns the base Content-Type of the request, stripping
+// any charset or other parameters.
+func (c *Context) ContentType() string {
+	return filterFlags(c.requestHeader("Content-Type"))
+}
+
+func (c *Context) requestHeader(key string) string {
+	if c.Request == nil {
+		return ""
+	}
+	return c.Request.Header.Get(key)
+}
+
+func filterFlags(content string) string {
+	if index := strings.IndexByte(content, ';'); index >= 0 {
+		return content[0:index]
+	}
+	return content
+}
+
+// RemoteIP returns the IP of the direct socket peer, ignoring any
+// forwarding headers. Most callers want ClientIP instead.
+func (c *Context) RemoteIP() string {
+	if ip, _, err := net.SplitHostPort(c.Request.RemoteAddr); err == nil {
+		return ip
+	}
+	return c.Request.RemoteAddr
+}
+
+// ClientIP returns the client's IP. If the direct socket peer
+// (RemoteIP) is not among the Engine's trusted proxies, forwarding
+// headers are ignored entirely and RemoteIP is returned as-is, since
+// an untrusted peer can set those headers to anything it likes. When
+// the peer is trusted, the Engine's RemoteIPHeaders are consulted in
+// order; for X-Forwarded-For the chain is walked right-to-left,
+// skipping further trusted proxies, to find the first address the
+// proxy chain itself didn't vouch for.
+func (c *Context) ClientIP() string {
+	remoteIP := c.RemoteIP()
+	if c.Engine == nil || !c.Engine.isTrustedProxy(remoteIP) {
+		return remoteIP
+	}
+
+	for _, header := range c.Engine.RemoteIPHeaders {
+		value := c.requestHeader(header)
+		if value == "" {
+			continue
+		}
+		if strings.EqualFold(header, "X-Forwarded-For") {
+			if ip := c.Engine.firstUntrustedForwardedFor(value); ip != "" {
+				return ip
+			}
+			continue
+		}
+		if ip := net.ParseIP(strings.TrimSpace(value)); ip != nil {
+			return ip.String()
+		}
+	}
+	return remoteIP
+}
+
+// Bind decodes the request into obj using the Binding that
+// binding.Default picks for the request's method and Content-Type
+// (the query string for GET, otherwise the body), writing a 400 and
+// aborting on failure.
+func (c *Context) Bind(obj interface{}) bool {
+	b := binding.Default(c.Request.Method, c.ContentType())
+	return c.BindWith(obj, b)
+}
+
+// BindWith decodes the request body into obj using b, writing a 400
+// and aborting on failure.
+func (c *Context) BindWith(obj interface{}, b binding.Binding) bool {
+	if err := b.Bind(c.Request, obj); err != nil {
+		c.Fail(400, err)
+		return false
+	}
+	return true
+}
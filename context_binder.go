@@ -0,0 +1,116 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// BindPath returns a binding.ValueBinder reading from the request's
+// path parameters. Coercion failures are appended to c.Errors rather
+// than aborting the request; call BindError() once the chain is done
+// to check whether anything failed.
+func (c *Context) BindPath() *binding.ValueBinder {
+	return binding.NewValueBinder(c.paramValues, c.recordBindError)
+}
+
+// BindQuery returns a binding.ValueBinder reading from the request's
+// query string. See BindPath for error handling semantics.
+func (c *Context) BindQuery() *binding.ValueBinder {
+	return binding.NewValueBinder(c.queryValues, c.recordBindError)
+}
+
+// BindHeader returns a binding.ValueBinder reading from the request's
+// headers. See BindPath for error handling semantics.
+func (c *Context) BindHeader() *binding.ValueBinder {
+	return binding.NewValueBinder(c.headerValues, c.recordBindError)
+}
+
+// BindForm returns a binding.ValueBinder reading from the request's
+// form fields (both URL query and POST/PUT body). See BindPath for
+// error handling semantics. A malformed or oversized form body is
+// itself recorded as a binding error rather than silently treated as
+// missing fields.
+func (c *Context) BindForm() *binding.ValueBinder {
+	b := binding.NewValueBinder(c.formValues, c.recordBindError)
+	return b.Invalid("form", c.parseForm())
+}
+
+// MustBindPath is the same as BindPath, but calls c.Fail(400, err) on
+// the first coercion failure, preserving the auto-abort behavior of
+// Bind/BindWith.
+func (c *Context) MustBindPath() *binding.ValueBinder {
+	return binding.NewValueBinder(c.paramValues, c.mustBindError())
+}
+
+// MustBindQuery is the same as BindQuery, but calls c.Fail(400, err)
+// on the first coercion failure.
+func (c *Context) MustBindQuery() *binding.ValueBinder {
+	return binding.NewValueBinder(c.queryValues, c.mustBindError())
+}
+
+// MustBindHeader is the same as BindHeader, but calls c.Fail(400, err)
+// on the first coercion failure.
+func (c *Context) MustBindHeader() *binding.ValueBinder {
+	return binding.NewValueBinder(c.headerValues, c.mustBindError())
+}
+
+// MustBindForm is the same as BindForm, but calls c.Fail(400, err) on
+// the first coercion failure.
+func (c *Context) MustBindForm() *binding.ValueBinder {
+	b := binding.NewValueBinder(c.formValues, c.mustBindError())
+	return b.Invalid("form", c.parseForm())
+}
+
+func (c *Context) recordBindError(err error) {
+	c.Error(err, "binding")
+}
+
+// mustBindError returns an error callback that fails the context on
+// the first error it sees and ignores the rest.
+func (c *Context) mustBindError() func(error) {
+	failed := false
+	return func(err error) {
+		if !failed {
+			failed = true
+			c.Fail(400, err)
+		}
+	}
+}
+
+func (c *Context) paramValues(name string) []string {
+	for _, p := range c.Params {
+		if p.Key == name {
+			return []string{p.Value}
+		}
+	}
+	return nil
+}
+
+func (c *Context) queryValues(name string) []string {
+	return c.Request.URL.Query()[name]
+}
+
+func (c *Context) headerValues(name string) []string {
+	return c.Request.Header.Values(name)
+}
+
+func (c *Context) formValues(name string) []string {
+	return c.Request.Form[name]
+}
+
+// parseForm populates c.Request.Form, only parsing a multipart body
+// when the request actually declares one; plain query strings and
+// application/x-www-form-urlencoded bodies go through ParseForm
+// instead, so a non-multipart request never surfaces
+// http.ErrNotMultipart as a spurious binding error.
+func (c *Context) parseForm() error {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		return c.Request.ParseMultipartForm(32 << 20)
+	}
+	return c.Request.ParseForm()
+}
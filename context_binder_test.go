@@ -0,0 +1,110 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextBindQuery(t *testing.T) {
+	c, _, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com/?id=42&active=true&ratio=0.5", nil)
+
+	var id int64
+	var active bool
+	var ratio float64
+	err := c.BindQuery().Int64("id", &id).Bool("active", &active).Float64("ratio", &ratio).BindError()
+
+	assert.Nil(t, err)
+	assert.Equal(t, id, int64(42))
+	assert.True(t, active)
+	assert.Equal(t, ratio, 0.5)
+}
+
+func TestContextBindQueryAccumulatesErrors(t *testing.T) {
+	c, _, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com/?id=not-a-number&active=not-a-bool", nil)
+
+	var id int64
+	var active bool
+	binder := c.BindQuery().Int64("id", &id).Bool("active", &active)
+
+	assert.NotNil(t, binder.BindError())
+	assert.Len(t, c.Errors, 2)
+	assert.False(t, c.IsAborted())
+}
+
+func TestContextBindPath(t *testing.T) {
+	c, _, _ := createTestContext()
+	c.Params = httprouter.Params{httprouter.Param{Key: "id", Value: "7"}}
+
+	var id int64
+	err := c.BindPath().Int64("id", &id).BindError()
+
+	assert.Nil(t, err)
+	assert.Equal(t, id, int64(7))
+}
+
+func TestContextBindHeader(t *testing.T) {
+	c, _, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com", nil)
+	c.Request.Header.Set("X-Request-Timeout", "1500ms")
+
+	var timeout time.Duration
+	err := c.BindHeader().Duration("X-Request-Timeout", &timeout).BindError()
+
+	assert.Nil(t, err)
+	assert.Equal(t, timeout, 1500*time.Millisecond)
+}
+
+func TestContextBindFormUnixTime(t *testing.T) {
+	c, _, _ := createTestContext()
+	c.Request, _ = http.NewRequest("POST", "http://example.com", strings.NewReader(url.Values{
+		"created_at": {"1500000000000"},
+	}.Encode()))
+	c.Request.Header.Set("Content-Type", MIMEPOSTForm)
+
+	var createdAt time.Time
+	err := c.BindForm().UnixTime("created_at", binding.UnixTimeMilliseconds, &createdAt).BindError()
+
+	assert.Nil(t, err)
+	assert.Equal(t, createdAt.Unix(), int64(1500000000))
+}
+
+// TestContextBindFormMalformedMultipart verifies that a broken
+// multipart body surfaces through BindError rather than being treated
+// as a missing field.
+func TestContextBindFormMalformedMultipart(t *testing.T) {
+	c, _, _ := createTestContext()
+	c.Request, _ = http.NewRequest("POST", "http://example.com", strings.NewReader("not-a-multipart-body"))
+	c.Request.Header.Set("Content-Type", "multipart/form-data; boundary=broken")
+
+	var name string
+	err := c.BindForm().String("name", &name).BindError()
+
+	assert.NotNil(t, err)
+	assert.Empty(t, name)
+	assert.Len(t, c.Errors, 1)
+}
+
+func TestContextMustBindQueryFails(t *testing.T) {
+	c, w, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com/?id=not-a-number", nil)
+
+	var id int64
+	c.MustBindQuery().Int64("id", &id)
+	c.Writer.WriteHeaderNow()
+
+	assert.True(t, c.IsAborted())
+	assert.Equal(t, w.Code, 400)
+}
@@ -251,20 +251,63 @@ func TestContextFail(t *testing.T) {
 }
 
 func TestContextClientIP(t *testing.T) {
-	c, _, _ := createTestContext()
+	c, _, r := createTestContext()
 	c.Request, _ = http.NewRequest("POST", "", nil)
 
 	c.Request.Header.Set("X-Real-IP", "10.10.10.10")
 	c.Request.Header.Set("X-Forwarded-For", "20.20.20.20 , 30.30.30.30")
 	c.Request.RemoteAddr = "40.40.40.40"
 
+	// 40.40.40.40 isn't a trusted proxy yet, so forwarding headers
+	// must be ignored, even though X-Real-IP was set by the caller.
+	assert.Equal(t, c.ClientIP(), "40.40.40.40")
+
+	// once trusted, the rightmost untrusted hop of X-Forwarded-For
+	// wins over X-Real-IP.
+	assert.NoError(t, r.SetTrustedProxies([]string{"40.40.40.40/32"}))
+	assert.Equal(t, c.ClientIP(), "30.30.30.30")
+
+	c.Request.Header.Del("X-Forwarded-For")
 	assert.Equal(t, c.ClientIP(), "10.10.10.10")
+
 	c.Request.Header.Del("X-Real-IP")
-	assert.Equal(t, c.ClientIP(), "20.20.20.20")
-	c.Request.Header.Del("X-Forwarded-For")
 	assert.Equal(t, c.ClientIP(), "40.40.40.40")
 }
 
+// TestContextClientIPUntrustedProxySpoofing guards against a peer that
+// isn't a trusted proxy setting X-Real-IP to whatever it likes.
+func TestContextClientIPUntrustedProxySpoofing(t *testing.T) {
+	c, _, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "", nil)
+	c.Request.Header.Set("X-Real-IP", "1.2.3.4")
+	c.Request.RemoteAddr = "9.9.9.9"
+
+	assert.Equal(t, c.ClientIP(), "9.9.9.9")
+}
+
+// TestContextClientIPForwardedChain walks past multiple trusted hops
+// to find the original client.
+func TestContextClientIPForwardedChain(t *testing.T) {
+	c, _, r := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "", nil)
+	c.Request.Header.Set("X-Forwarded-For", "2.2.2.2, 10.0.0.1, 10.0.0.2")
+	c.Request.RemoteAddr = "10.0.0.2"
+
+	assert.NoError(t, r.SetTrustedProxies([]string{"10.0.0.0/24"}))
+	assert.Equal(t, c.ClientIP(), "2.2.2.2")
+}
+
+// TestContextClientIPIPv6 exercises the IPv6 CIDR and header path.
+func TestContextClientIPIPv6(t *testing.T) {
+	c, _, r := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "", nil)
+	c.Request.Header.Set("X-Forwarded-For", "2001:db8::1, fe80::1")
+	c.Request.RemoteAddr = "fe80::1"
+
+	assert.NoError(t, r.SetTrustedProxies([]string{"fe80::/10"}))
+	assert.Equal(t, c.ClientIP(), "2001:db8::1")
+}
+
 func TestContextContentType(t *testing.T) {
 	c, _, _ := createTestContext()
 	c.Request, _ = http.NewRequest("POST", "", nil)
@@ -287,6 +330,21 @@ func TestContextAutoBind(t *testing.T) {
 	assert.Equal(t, w.Body.Len(), 0)
 }
 
+// TestContextAutoBindGet verifies that c.Bind on a GET request binds
+// from the query string instead of panicking on a nil Binding.
+func TestContextAutoBindGet(t *testing.T) {
+	c, w, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com?foo=bar&bar=foo", nil)
+	var obj struct {
+		Foo string `form:"foo"`
+		Bar string `form:"bar"`
+	}
+	assert.True(t, c.Bind(&obj))
+	assert.Equal(t, obj.Bar, "foo")
+	assert.Equal(t, obj.Foo, "bar")
+	assert.Equal(t, w.Body.Len(), 0)
+}
+
 func TestContextBadAutoBind(t *testing.T) {
 	c, w, _ := createTestContext()
 	c.Request, _ = http.NewRequest("POST", "http://example.com", bytes.NewBufferString("\"foo\":\"bar\", \"bar\":\"foo\"}"))
@@ -319,3 +377,62 @@ func TestContextBindWith(t *testing.T) {
 	assert.Equal(t, obj.Foo, "bar")
 	assert.Equal(t, w.Body.Len(), 0)
 }
+
+// Tests that the response is wrapped in the callback named by the
+// `callback` query parameter and Content-Type is set to
+// application/javascript
+func TestContextRenderJSONP(t *testing.T) {
+	c, w, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com/?callback=x", nil)
+	c.JSONP(201, H{"foo": "bar"})
+
+	assert.Equal(t, w.Code, 201)
+	assert.Equal(t, w.Body.String(), "/**/typeof x === 'function' && x({\"foo\":\"bar\"});")
+	assert.Equal(t, w.HeaderMap.Get("Content-Type"), "application/javascript; charset=utf-8")
+}
+
+// Tests that a callback attempting to inject a script tag is rejected
+// and the response falls back to plain JSON
+func TestContextRenderJSONPRejectsInvalidCallback(t *testing.T) {
+	c, w, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com/?callback=%3Cscript%3Ealert(1)%3C%2Fscript%3E", nil)
+	c.JSONP(201, H{"foo": "bar"})
+
+	assert.Equal(t, w.Code, 201)
+	assert.Equal(t, w.Body.String(), "{\"foo\":\"bar\"}\n")
+	assert.Equal(t, w.HeaderMap.Get("Content-Type"), "application/json; charset=utf-8")
+}
+
+// Tests that a missing callback query parameter falls back to plain
+// JSON
+func TestContextRenderJSONPNoCallback(t *testing.T) {
+	c, w, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com/", nil)
+	c.JSONP(201, H{"foo": "bar"})
+
+	assert.Equal(t, w.Code, 201)
+	assert.Equal(t, w.Body.String(), "{\"foo\":\"bar\"}\n")
+	assert.Equal(t, w.HeaderMap.Get("Content-Type"), "application/json; charset=utf-8")
+}
+
+// Tests that JSONPretty indents its output
+func TestContextRenderJSONPretty(t *testing.T) {
+	c, w, _ := createTestContext()
+	c.JSONPretty(201, H{"foo": "bar"}, "  ")
+
+	assert.Equal(t, w.Code, 201)
+	assert.Equal(t, w.Body.String(), "{\n  \"foo\": \"bar\"\n}")
+	assert.Equal(t, w.HeaderMap.Get("Content-Type"), "application/json; charset=utf-8")
+}
+
+// Tests that a `?pretty` query parameter switches c.JSON to indented
+// output automatically
+func TestContextRenderJSONWithPrettyQuery(t *testing.T) {
+	c, w, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com/?pretty", nil)
+	c.JSON(201, H{"foo": "bar"})
+
+	assert.Equal(t, w.Code, 201)
+	assert.Equal(t, w.Body.String(), "{\n  \"foo\": \"bar\"\n}")
+	assert.Equal(t, w.HeaderMap.Get("Content-Type"), "application/json; charset=utf-8")
+}
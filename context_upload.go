@@ -0,0 +1,116 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+const defaultMultipartMemory = 32 << 20 // 32 MB
+
+// FormFile returns the first file for the given multipart form key.
+func (c *Context) FormFile(name string) (*multipart.FileHeader, error) {
+	if c.Request.MultipartForm == nil {
+		if err := c.Request.ParseMultipartForm(defaultMultipartMemory); err != nil {
+			return nil, err
+		}
+	}
+	_, fh, err := c.Request.FormFile(name)
+	return fh, err
+}
+
+// MultipartForm parses and returns the request's multipart form.
+func (c *Context) MultipartForm() (*multipart.Form, error) {
+	err := c.Request.ParseMultipartForm(defaultMultipartMemory)
+	return c.Request.MultipartForm, err
+}
+
+// SaveUploadedFile copies an uploaded file to dst on disk.
+func (c *Context) SaveUploadedFile(file *multipart.FileHeader, dst string) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, src)
+	return err
+}
+
+// File writes the named file to the response, letting http.ServeFile
+// handle range requests and content sniffing.
+func (c *Context) File(filepath string) {
+	http.ServeFile(c.Writer, c.Request, filepath)
+}
+
+// Attachment writes the named file to the response with a
+// Content-Disposition header that prompts the browser to download it
+// as name, rather than render it inline.
+func (c *Context) Attachment(filepath, name string) {
+	c.Writer.Header().Set("Content-Disposition", contentDispositionAttachment(name))
+	c.File(filepath)
+}
+
+// contentDispositionAttachment builds a Content-Disposition header
+// value for name. Non-ASCII names get an RFC 5987 filename* parameter
+// alongside an ASCII-safe filename fallback, so browsers that don't
+// understand filename* still get a sane download name.
+func contentDispositionAttachment(name string) string {
+	if isASCII(name) {
+		return fmt.Sprintf(`attachment; filename="%s"`, name)
+	}
+	return fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, asciiFallback(name), url.PathEscape(name))
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > 127 {
+			return false
+		}
+	}
+	return true
+}
+
+func asciiFallback(s string) string {
+	out := make([]byte, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] > 127 {
+			out[i] = '_'
+		} else {
+			out[i] = s[i]
+		}
+	}
+	return string(out)
+}
+
+// Stream repeatedly calls step with the response writer, flushing
+// after every call, until step returns false or the client
+// disconnects.
+func (c *Context) Stream(step func(w io.Writer) bool) {
+	clientGone := c.Request.Context().Done()
+	for {
+		select {
+		case <-clientGone:
+			return
+		default:
+			keepOpen := step(c.Writer)
+			c.Writer.Flush()
+			if !keepOpen {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMultipartRequest(t *testing.T, fieldName, fileName, content string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(fieldName, fileName)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req, err := http.NewRequest("POST", "http://example.com/upload", body)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestContextFormFile(t *testing.T) {
+	c, _, _ := createTestContext()
+	c.Request = newMultipartRequest(t, "file", "hello.txt", "hello world")
+
+	fh, err := c.FormFile("file")
+	assert.NoError(t, err)
+	assert.Equal(t, fh.Filename, "hello.txt")
+}
+
+func TestContextSaveUploadedFile(t *testing.T) {
+	c, _, _ := createTestContext()
+	c.Request = newMultipartRequest(t, "file", "hello.txt", "hello world")
+
+	fh, err := c.FormFile("file")
+	assert.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "saved.txt")
+	assert.NoError(t, c.SaveUploadedFile(fh, dst))
+
+	saved, err := os.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, string(saved), "hello world")
+}
+
+func TestContextAttachmentASCIIName(t *testing.T) {
+	c, w, _ := createTestContext()
+	dst := filepath.Join(t.TempDir(), "report.csv")
+	assert.NoError(t, os.WriteFile(dst, []byte("a,b\n"), 0o644))
+	c.Request, _ = http.NewRequest("GET", "http://example.com/download", nil)
+
+	c.Attachment(dst, "report.csv")
+
+	assert.Equal(t, w.HeaderMap.Get("Content-Disposition"), `attachment; filename="report.csv"`)
+}
+
+func TestContextAttachmentNonASCIIName(t *testing.T) {
+	c, w, _ := createTestContext()
+	dst := filepath.Join(t.TempDir(), "report.csv")
+	assert.NoError(t, os.WriteFile(dst, []byte("a,b\n"), 0o644))
+	c.Request, _ = http.NewRequest("GET", "http://example.com/download", nil)
+
+	c.Attachment(dst, "报告.csv")
+
+	assert.Equal(t, w.HeaderMap.Get("Content-Disposition"), `attachment; filename="______.csv"; filename*=UTF-8''%E6%8A%A5%E5%91%8A.csv`)
+}
+
+func TestContextStreamStopsWhenStepReturnsFalse(t *testing.T) {
+	c, w, _ := createTestContext()
+	c.Request, _ = http.NewRequest("GET", "http://example.com/stream", nil)
+
+	calls := 0
+	c.Stream(func(writer io.Writer) bool {
+		calls++
+		io.WriteString(writer, "chunk\n")
+		return calls < 3
+	})
+
+	assert.Equal(t, calls, 3)
+	assert.Equal(t, w.Body.String(), "chunk\nchunk\nchunk\n")
+}
+
+func TestContextStreamStopsWhenClientDisconnects(t *testing.T) {
+	c, _, _ := createTestContext()
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequest("GET", "http://example.com/stream", nil)
+	c.Request = req.WithContext(ctx)
+
+	calls := 0
+	cancel()
+	c.Stream(func(writer io.Writer) bool {
+		calls++
+		return true
+	})
+
+	assert.Equal(t, calls, 0)
+}
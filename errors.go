@@ -0,0 +1,56 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ErrorType classifies the origin of an error appended to Context.Errors.
+type ErrorType uint64
+
+const (
+	ErrorTypeInternal ErrorType = 1 << iota
+	ErrorTypeExternal
+	// ErrorTypePanic marks an error recorded by Recovery() after
+	// catching a panic in a handler.
+	ErrorTypePanic
+	ErrorTypeAll = 0xffffffffffffffff
+)
+
+// ErrorMsg represents a single error together with the metadata and
+// type it was recorded with.
+type ErrorMsg struct {
+	Err  string      `json:"error"`
+	Type ErrorType   `json:"-"`
+	Meta interface{} `json:"meta"`
+}
+
+type errorMsgs []ErrorMsg
+
+func (a errorMsgs) ByType(typ ErrorType) errorMsgs {
+	if len(a) == 0 {
+		return a
+	}
+	result := make(errorMsgs, 0, len(a))
+	for _, msg := range a {
+		if msg.Type&typ > 0 {
+			result = append(result, msg)
+		}
+	}
+	return result
+}
+
+func (a errorMsgs) String() string {
+	if len(a) == 0 {
+		return ""
+	}
+	var buffer bytes.Buffer
+	for i, msg := range a {
+		fmt.Fprintf(&buffer, "Error #%02d: %s\n     Meta: %v\n", i+1, msg.Err, msg.Meta)
+	}
+	return buffer.String()
+}
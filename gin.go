@@ -0,0 +1,135 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"encoding/xml"
+	"html/template"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin/render"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	MIMEJSON     = "application/json"
+	MIMEHTML     = "text/html"
+	MIMEXML      = "application/xml"
+	MIMEXML2     = "application/xml; charset=utf-8"
+	MIMEPlain    = "text/plain"
+	MIMEPOSTForm = "application/x-www-form-urlencoded"
+)
+
+// H is a shortcut for map[string]interface{}, commonly used to build
+// JSON/XML payloads.
+type H map[string]interface{}
+
+// MarshalXML allows H to be marshaled by encoding/xml, which otherwise
+// refuses to encode plain maps.
+func (h H) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "map"}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for key, value := range h {
+		elem := xml.StartElement{Name: xml.Name{Local: key}}
+		if err := e.EncodeElement(value, elem); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// HandlerFunc is the type of function gin uses to handle a request
+// within a middleware or route chain.
+type HandlerFunc func(*Context)
+
+// HandlersChain is a list of handlers executed in order for a request.
+type HandlersChain []HandlerFunc
+
+// Last returns the last handler in the chain, typically the final
+// route action.
+func (c HandlersChain) Last() HandlerFunc {
+	if length := len(c); length > 0 {
+		return c[length-1]
+	}
+	return nil
+}
+
+// Engine is the framework's instance, it contains the muxer, middleware
+// and configuration settings. Create an instance with New() or Default().
+type Engine struct {
+	*RouterGroup
+
+	router     *httprouter.Router
+	pool       sync.Pool
+	HTMLRender render.HTMLRender
+
+	// RemoteIPHeaders is the ordered list of headers consulted by
+	// Context.ClientIP once the immediate peer is a trusted proxy.
+	RemoteIPHeaders []string
+
+	trustedCIDRs []*net.IPNet
+}
+
+// New returns a new blank Engine instance without any middleware attached.
+func New() *Engine {
+	engine := &Engine{
+		router:          httprouter.New(),
+		RemoteIPHeaders: []string{"X-Forwarded-For", "X-Real-IP"},
+	}
+	engine.RouterGroup = &RouterGroup{
+		basePath: "/",
+		engine:   engine,
+	}
+	engine.pool.New = func() interface{} {
+		return engine.allocateContext()
+	}
+	return engine
+}
+
+// Default returns an Engine instance with the Recovery middleware
+// already attached.
+func Default() *Engine {
+	engine := New()
+	engine.Use(Recovery())
+	return engine
+}
+
+func (engine *Engine) allocateContext() *Context {
+	return &Context{Engine: engine}
+}
+
+// SetHTMLTemplate registers a pre-parsed set of HTML templates to be
+// used by Context.HTML.
+func (engine *Engine) SetHTMLTemplate(templ *template.Template) {
+	engine.HTMLRender = render.HTMLProduction{Template: templ}
+}
+
+func (engine *Engine) createContext(w http.ResponseWriter, req *http.Request, params httprouter.Params, handlers HandlersChain) *Context {
+	c := engine.pool.Get().(*Context)
+	c.writermem.reset(w)
+	c.Request = req
+	c.reset()
+	c.Params = params
+	c.handlers = handlers
+	return c
+}
+
+func (engine *Engine) reuseContext(c *Context) {
+	engine.pool.Put(c)
+}
+
+// ServeHTTP makes the Engine implement the http.Handler interface.
+func (engine *Engine) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	engine.router.ServeHTTP(w, req)
+}
+
+// Run attaches the Engine to an http.Server and starts listening on addr.
+func (engine *Engine) Run(addr string) error {
+	return http.ListenAndServe(addr, engine)
+}
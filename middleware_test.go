@@ -0,0 +1,102 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func performRequest(r http.Handler, method, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddlewareGeneralCase(t *testing.T) {
+	signature := ""
+	router := New()
+	router.Use(func(c *Context) {
+		signature += "A"
+		c.Next()
+		signature += "B"
+	})
+	router.Use(func(c *Context) {
+		signature += "C"
+		c.Next()
+	})
+	router.GET("/", func(c *Context) {
+		signature += "D"
+	})
+
+	w := performRequest(router, "GET", "/")
+
+	assert.Equal(t, w.Code, 200)
+	assert.Equal(t, signature, "ACDB")
+}
+
+func TestMiddlewareAbort(t *testing.T) {
+	signature := ""
+	router := New()
+	router.Use(func(c *Context) {
+		signature += "A"
+	})
+	router.Use(func(c *Context) {
+		signature += "C"
+		c.AbortWithStatus(409)
+		c.Next()
+		signature += "D"
+	})
+	router.GET("/", func(c *Context) {
+		signature += " princess"
+	})
+
+	w := performRequest(router, "GET", "/")
+
+	assert.Equal(t, w.Code, 409)
+	assert.Equal(t, signature, "ACD")
+}
+
+func TestRouterGroupInheritsMiddleware(t *testing.T) {
+	signature := ""
+	router := New()
+	router.Use(func(c *Context) {
+		signature += "A"
+		c.Next()
+	})
+
+	admin := router.Group("/admin", func(c *Context) {
+		signature += "C"
+		c.Next()
+	})
+	admin.GET("/", func(c *Context) {
+		signature += "D"
+	})
+
+	w := performRequest(router, "GET", "/admin/")
+
+	assert.Equal(t, w.Code, 200)
+	assert.Equal(t, signature, "ACD")
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	router := New()
+	var captured *Context
+	router.Use(Recovery())
+	router.GET("/panic", func(c *Context) {
+		captured = c
+		panic("boom")
+	})
+
+	w := performRequest(router, "GET", "/panic")
+
+	assert.Equal(t, w.Code, 500)
+	assert.Equal(t, len(captured.Errors), 1)
+	assert.Equal(t, captured.Errors[0].Type, ErrorTypePanic)
+}
@@ -0,0 +1,27 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Recovery returns a middleware that recovers from any panic in a
+// later handler, fails the request with a 500 and records the panic
+// (with its stack trace) as an ErrorTypePanic error, instead of
+// crashing the server.
+func Recovery() HandlerFunc {
+	return func(c *Context) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err := fmt.Errorf("%v", rec)
+				c.ErrorTyped(err, ErrorTypePanic, string(debug.Stack()))
+				c.AbortWithStatus(500)
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import "net/http"
+
+// Data writes a raw byte slice as the given Content-Type.
+type Data struct {
+	ContentType string
+	Data        []byte
+}
+
+func (r Data) Render(w http.ResponseWriter) (err error) {
+	if len(r.ContentType) > 0 {
+		writeHeader(w, r.ContentType)
+	}
+	_, err = w.Write(r.Data)
+	return
+}
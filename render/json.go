@@ -0,0 +1,22 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// JSON marshals the given object with the standard encoding/json
+// encoder and writes it as application/json.
+type JSON struct {
+	Data interface{}
+}
+
+func (r JSON) Render(w http.ResponseWriter) error {
+	writeHeader(w, "application/json; charset=utf-8")
+	encoder := json.NewEncoder(w)
+	return encoder.Encode(r.Data)
+}
@@ -0,0 +1,55 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// jsonpCallbackName matches a valid JavaScript identifier (optionally
+// dotted, e.g. "ns.callback"), used to keep untrusted callback names
+// out of the response body.
+var jsonpCallbackName = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$.]*$`)
+
+// JSONP wraps Data in a JSONP callback, falling back to plain JSON
+// when Callback is empty or fails validation.
+type JSONP struct {
+	Data     interface{}
+	Callback string
+}
+
+func (r JSONP) Render(w http.ResponseWriter) error {
+	if r.Callback == "" || !jsonpCallbackName.MatchString(r.Callback) {
+		return JSON{Data: r.Data}.Render(w)
+	}
+
+	writeHeader(w, "application/javascript; charset=utf-8")
+	body, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "/**/typeof %s === 'function' && %s(%s);", r.Callback, r.Callback, body)
+	return err
+}
+
+// IndentedJSON marshals Data as JSON indented with Indent, useful for
+// human-readable debugging responses.
+type IndentedJSON struct {
+	Data   interface{}
+	Indent string
+}
+
+func (r IndentedJSON) Render(w http.ResponseWriter) error {
+	writeHeader(w, "application/json; charset=utf-8")
+	body, err := json.MarshalIndent(r.Data, "", r.Indent)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
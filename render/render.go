@@ -0,0 +1,69 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// Render defines the interface for writing an object to the response
+// with the correct Content-Type header.
+type Render interface {
+	Render(http.ResponseWriter) error
+}
+
+// HTMLRender is implemented by anything that can produce a Render from
+// a template name and its data.
+type HTMLRender interface {
+	Instance(string, interface{}) Render
+}
+
+var (
+	_ Render     = JSON{}
+	_ Render     = JSONP{}
+	_ Render     = IndentedJSON{}
+	_ Render     = XML{}
+	_ Render     = String{}
+	_ Render     = Redirect{}
+	_ Render     = Data{}
+	_ Render     = HTML{}
+	_ HTMLRender = HTMLProduction{}
+)
+
+func writeHeader(w http.ResponseWriter, contentType string) {
+	header := w.Header()
+	if val := header.Get("Content-Type"); len(val) == 0 {
+		header.Set("Content-Type", contentType)
+	}
+}
+
+// HTMLProduction renders templates from a preloaded html/template.Template.
+type HTMLProduction struct {
+	Template *template.Template
+}
+
+// HTML holds the state needed to execute a named template with data.
+type HTML struct {
+	Template *template.Template
+	Name     string
+	Data     interface{}
+}
+
+func (r HTMLProduction) Instance(name string, data interface{}) Render {
+	return HTML{
+		Template: r.Template,
+		Name:     name,
+		Data:     data,
+	}
+}
+
+func (r HTML) Render(w http.ResponseWriter) error {
+	writeHeader(w, "text/html; charset=utf-8")
+	if len(r.Name) == 0 {
+		return r.Template.Execute(w, r.Data)
+	}
+	return r.Template.ExecuteTemplate(w, r.Name, r.Data)
+}
@@ -0,0 +1,27 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// String formats the given values with fmt.Sprintf and writes them as
+// text/plain.
+type String struct {
+	Format string
+	Data   []interface{}
+}
+
+func (r String) Render(w http.ResponseWriter) error {
+	writeHeader(w, "text/plain; charset=utf-8")
+	if len(r.Data) > 0 {
+		fmt.Fprintf(w, r.Format, r.Data...)
+	} else {
+		fmt.Fprint(w, r.Format)
+	}
+	return nil
+}
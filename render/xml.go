@@ -0,0 +1,22 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// XML marshals the given object with the standard encoding/xml encoder
+// and writes it as application/xml.
+type XML struct {
+	Data interface{}
+}
+
+func (r XML) Render(w http.ResponseWriter) error {
+	writeHeader(w, "application/xml; charset=utf-8")
+	encoder := xml.NewEncoder(w)
+	return encoder.Encode(r.Data)
+}
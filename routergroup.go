@@ -0,0 +1,137 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"net/http"
+	"path"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// IRouter defines the methods needed to register routes, shared by the
+// Engine and every RouterGroup.
+type IRouter interface {
+	Use(...HandlerFunc) *RouterGroup
+
+	Handle(string, string, ...HandlerFunc) *RouterGroup
+	GET(string, ...HandlerFunc) *RouterGroup
+	POST(string, ...HandlerFunc) *RouterGroup
+	PUT(string, ...HandlerFunc) *RouterGroup
+	DELETE(string, ...HandlerFunc) *RouterGroup
+	PATCH(string, ...HandlerFunc) *RouterGroup
+	HEAD(string, ...HandlerFunc) *RouterGroup
+	OPTIONS(string, ...HandlerFunc) *RouterGroup
+
+	Group(string, ...HandlerFunc) *RouterGroup
+}
+
+// RouterGroup is used internally to configure a router, a RouterGroup
+// is associated with a prefix and an array of handlers (middleware).
+type RouterGroup struct {
+	Handlers HandlersChain
+	basePath string
+	engine   *Engine
+}
+
+var _ IRouter = (*RouterGroup)(nil)
+
+// Use adds middleware to the group, applied to routes registered on it
+// and groups derived from it after this call; routes and groups
+// created earlier keep the middleware they were created with.
+func (group *RouterGroup) Use(middleware ...HandlerFunc) *RouterGroup {
+	group.Handlers = append(group.Handlers, middleware...)
+	return group
+}
+
+// Group creates a new RouterGroup nested under the current one, sharing
+// its middleware and base path.
+func (group *RouterGroup) Group(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return &RouterGroup{
+		Handlers: group.combineHandlers(handlers),
+		basePath: group.calculateAbsolutePath(relativePath),
+		engine:   group.engine,
+	}
+}
+
+// Handle registers a new request handle and middleware with the given
+// path and method, building a HandlersChain out of the group's
+// middleware plus the handlers passed in.
+func (group *RouterGroup) Handle(httpMethod, relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	absolutePath := group.calculateAbsolutePath(relativePath)
+	chain := group.combineHandlers(handlers)
+	group.engine.router.Handle(httpMethod, absolutePath, group.handle(chain))
+	return group
+}
+
+func (group *RouterGroup) handle(handlers HandlersChain) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+		c := group.engine.createContext(w, req, params, handlers)
+		c.Next()
+		c.writermem.WriteHeaderNow()
+		group.engine.reuseContext(c)
+	}
+}
+
+// GET registers a handler chain for GET requests on relativePath.
+func (group *RouterGroup) GET(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return group.Handle("GET", relativePath, handlers...)
+}
+
+// POST registers a handler chain for POST requests on relativePath.
+func (group *RouterGroup) POST(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return group.Handle("POST", relativePath, handlers...)
+}
+
+// PUT registers a handler chain for PUT requests on relativePath.
+func (group *RouterGroup) PUT(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return group.Handle("PUT", relativePath, handlers...)
+}
+
+// DELETE registers a handler chain for DELETE requests on relativePath.
+func (group *RouterGroup) DELETE(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return group.Handle("DELETE", relativePath, handlers...)
+}
+
+// PATCH registers a handler chain for PATCH requests on relativePath.
+func (group *RouterGroup) PATCH(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return group.Handle("PATCH", relativePath, handlers...)
+}
+
+// HEAD registers a handler chain for HEAD requests on relativePath.
+func (group *RouterGroup) HEAD(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return group.Handle("HEAD", relativePath, handlers...)
+}
+
+// OPTIONS registers a handler chain for OPTIONS requests on relativePath.
+func (group *RouterGroup) OPTIONS(relativePath string, handlers ...HandlerFunc) *RouterGroup {
+	return group.Handle("OPTIONS", relativePath, handlers...)
+}
+
+func (group *RouterGroup) combineHandlers(handlers HandlersChain) HandlersChain {
+	finalSize := len(group.Handlers) + len(handlers)
+	mergedHandlers := make(HandlersChain, finalSize)
+	copy(mergedHandlers, group.Handlers)
+	copy(mergedHandlers[len(group.Handlers):], handlers)
+	return mergedHandlers
+}
+
+func (group *RouterGroup) calculateAbsolutePath(relativePath string) string {
+	if len(relativePath) == 0 {
+		return group.basePath
+	}
+	absolutePath := path.Join(group.basePath, relativePath)
+	if lastChar(relativePath) == '/' && lastChar(absolutePath) != '/' {
+		return absolutePath + "/"
+	}
+	return absolutePath
+}
+
+func lastChar(str string) uint8 {
+	if len(str) == 0 {
+		panic("The length of the string can't be 0")
+	}
+	return str[len(str)-1]
+}
@@ -0,0 +1,58 @@
+// Copyright 2014 Manu Martinez-Almeida.  All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file.
+
+package gin
+
+import (
+	"net"
+	"strings"
+)
+
+// SetTrustedProxies configures the set of CIDRs (IPv4 or IPv6) whose
+// forwarding headers Context.ClientIP is willing to trust. Until this
+// is called, no proxy is trusted and forwarding headers are ignored.
+func (engine *Engine) SetTrustedProxies(trustedProxies []string) error {
+	cidrs := make([]*net.IPNet, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		_, cidr, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return err
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	engine.trustedCIDRs = cidrs
+	return nil
+}
+
+func (engine *Engine) isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, cidr := range engine.trustedCIDRs {
+		if cidr.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstUntrustedForwardedFor walks a comma-separated X-Forwarded-For
+// chain right-to-left (closest hop first), skipping any entry that is
+// itself a trusted proxy, and returns the first one that isn't. It
+// returns "" if every entry in the chain is trusted.
+func (engine *Engine) firstUntrustedForwardedFor(chain string) string {
+	entries := strings.Split(chain, ",")
+	for i := len(entries) - 1; i >= 0; i-- {
+		candidate := strings.TrimSpace(entries[i])
+		ip := net.ParseIP(candidate)
+		if ip == nil {
+			continue
+		}
+		if !engine.isTrustedProxy(ip.String()) {
+			return ip.String()
+		}
+	}
+	return ""
+}